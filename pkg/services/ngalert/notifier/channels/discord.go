@@ -0,0 +1,150 @@
+package channels
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/prometheus/alertmanager/template"
+	"github.com/prometheus/alertmanager/types"
+	"github.com/prometheus/common/model"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/models"
+	"github.com/grafana/grafana/pkg/services/notifications"
+	"github.com/grafana/grafana/pkg/setting"
+)
+
+const defaultDiscordMessage = `{{ template "default.message" . }}`
+
+// discordColorFiring and discordColorResolved are the embed colors Discord renders as a colored
+// bar down the left edge of the message, matching the colors Grafana uses elsewhere to flag an
+// alert group's state.
+const (
+	discordColorFiring   = 0xD63232
+	discordColorResolved = 0x36A64F
+)
+
+const discordAvatarURL = "https://grafana.com/assets/img/dp-logo.png"
+
+// DiscordConfig holds the settings needed to post alert notifications to a Discord channel
+// through an incoming webhook.
+type DiscordConfig struct {
+	*NotificationChannelConfig
+
+	WebhookURL         string
+	AvatarURL          string
+	Message            string
+	UseDiscordUsername bool
+}
+
+// NewDiscordConfig parses and validates the notification channel settings for a Discord channel.
+func NewDiscordConfig(config *NotificationChannelConfig) (*DiscordConfig, error) {
+	webhookURL := config.Settings.Get("url").MustString()
+	if webhookURL == "" {
+		return nil, errors.New("could not find webhook url property in settings")
+	}
+	return &DiscordConfig{
+		NotificationChannelConfig: config,
+		WebhookURL:                webhookURL,
+		AvatarURL:                 config.Settings.Get("avatar_url").MustString(),
+		Message:                   config.Settings.Get("message").MustString(defaultDiscordMessage),
+		UseDiscordUsername:        config.Settings.Get("use_discord_username").MustBool(false),
+	}, nil
+}
+
+// DiscordNotifier posts alert notifications to a Discord channel through an incoming webhook.
+type DiscordNotifier struct {
+	conf *DiscordConfig
+	log  log.Logger
+	ns   notifications.WebhookSender
+	tmpl *template.Template
+}
+
+// NewDiscordNotifier returns a new notifier that posts alerts to Discord.
+func NewDiscordNotifier(conf *DiscordConfig, ns notifications.WebhookSender, t *template.Template) *DiscordNotifier {
+	return &DiscordNotifier{
+		conf: conf,
+		log:  log.New("alerting.notifier.discord"),
+		ns:   ns,
+		tmpl: t,
+	}
+}
+
+type discordFooter struct {
+	IconURL string `json:"icon_url"`
+	Text    string `json:"text"`
+}
+
+type discordEmbed struct {
+	Title  string        `json:"title"`
+	URL    string        `json:"url"`
+	Type   string        `json:"type"`
+	Color  int           `json:"color"`
+	Footer discordFooter `json:"footer"`
+}
+
+type discordMessage struct {
+	Username  string         `json:"username,omitempty"`
+	AvatarURL string         `json:"avatar_url,omitempty"`
+	Content   string         `json:"content"`
+	Embeds    []discordEmbed `json:"embeds"`
+}
+
+// Notify implements the Notifier interface. A broken message template fails the notification
+// outright rather than posting a Discord message with a blank or garbled content field.
+func (dn *DiscordNotifier) Notify(ctx context.Context, as ...*types.Alert) (bool, error) {
+	var tmplErr error
+	tmpl, _ := TmplText(ctx, dn.tmpl, as, dn.log, &tmplErr)
+
+	color := discordColorFiring
+	if types.Alerts(as...).Status() == model.AlertResolved {
+		color = discordColorResolved
+	}
+
+	msg := &discordMessage{
+		AvatarURL: dn.conf.AvatarURL,
+		Content:   tmpl(dn.conf.Message),
+		Embeds: []discordEmbed{
+			{
+				Title: tmpl(`{{ template "default.title" . }}`),
+				URL:   strings.TrimRight(dn.tmpl.ExternalURL.String(), "/") + "/alerting/list",
+				Type:  "rich",
+				Color: color,
+				Footer: discordFooter{
+					IconURL: discordAvatarURL,
+					Text:    "Grafana v" + setting.BuildVersion,
+				},
+			},
+		},
+	}
+	if !dn.conf.UseDiscordUsername {
+		msg.Username = "Grafana"
+	}
+	if tmplErr != nil {
+		return false, tmplErr
+	}
+
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return false, fmt.Errorf("marshal Discord message: %w", err)
+	}
+
+	cmd := &models.SendWebhookSync{
+		Url:        dn.conf.WebhookURL,
+		Body:       string(body),
+		HttpMethod: http.MethodPost,
+	}
+	if err := dn.ns.SendWebhookSync(ctx, cmd); err != nil {
+		dn.log.Error("failed to send Discord notification", "error", err, "webhook", dn.conf.WebhookURL)
+		return false, err
+	}
+	return true, nil
+}
+
+func (dn *DiscordNotifier) SendResolved() bool {
+	return !dn.conf.DisableResolveMessage
+}
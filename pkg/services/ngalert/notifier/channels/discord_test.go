@@ -137,6 +137,36 @@ func TestDiscordNotifier(t *testing.T) {
 			},
 			expMsgError: nil,
 		},
+		{
+			name: "Custom message using the shared template funcs",
+			settings: `{
+				"url": "http://localhost",
+				"message": "{{ Join (.CommonLabels.SortedPairs.Names) \", \" | ToUpper }}"
+			}`,
+			alerts: []*types.Alert{
+				{
+					Alert: model.Alert{
+						Labels:      model.LabelSet{"alertname": "alert1", "lbl1": "val1"},
+						Annotations: model.LabelSet{"ann1": "annv1"},
+					},
+				},
+			},
+			expMsg: map[string]interface{}{
+				"content": "ALERTNAME, LBL1",
+				"embeds": []interface{}{map[string]interface{}{
+					"color": 1.4037554e+07,
+					"footer": map[string]interface{}{
+						"icon_url": "https://grafana.com/assets/img/dp-logo.png",
+						"text":     "Grafana v" + setting.BuildVersion,
+					},
+					"title": "[FIRING:1]  (val1)",
+					"url":   "http://localhost/alerting/list",
+					"type":  "rich",
+				}},
+				"username": "Grafana",
+			},
+			expMsgError: nil,
+		},
 	}
 
 	for _, c := range cases {
@@ -178,3 +208,60 @@ func TestDiscordNotifier(t *testing.T) {
 		})
 	}
 }
+
+func TestDiscordNotifier_EmbedColorByStatus(t *testing.T) {
+	tmpl := templateForTests(t)
+
+	externalURL, err := url.Parse("http://localhost")
+	require.NoError(t, err)
+	tmpl.ExternalURL = externalURL
+
+	settingsJson, err := simplejson.NewJson([]byte(`{"url": "http://localhost"}`))
+	require.NoError(t, err)
+	cfg, err := NewDiscordConfig(&NotificationChannelConfig{
+		Name:     "discord_testing",
+		Type:     "discord",
+		Settings: settingsJson,
+	})
+	require.NoError(t, err)
+
+	cases := []struct {
+		name     string
+		alerts   []*types.Alert
+		expColor float64
+	}{
+		{
+			name: "Firing alert uses the firing color",
+			alerts: []*types.Alert{
+				{Alert: model.Alert{Labels: model.LabelSet{"alertname": "alert1"}}},
+			},
+			expColor: 1.4037554e+07,
+		},
+		{
+			name: "Resolved alert uses the resolved color",
+			alerts: []*types.Alert{
+				{Alert: model.Alert{Labels: model.LabelSet{"alertname": "alert1"}, EndsAt: model.Now()}},
+			},
+			expColor: 3.581519e+06,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			webhookSender := mockNotificationService()
+			ctx := notify.WithGroupKey(context.Background(), "alertname")
+			ctx = notify.WithGroupLabels(ctx, model.LabelSet{"alertname": ""})
+
+			dn := NewDiscordNotifier(cfg, webhookSender, tmpl)
+			ok, err := dn.Notify(ctx, c.alerts...)
+			require.NoError(t, err)
+			require.True(t, ok)
+
+			var body map[string]interface{}
+			require.NoError(t, json.Unmarshal([]byte(webhookSender.Webhook.Body), &body))
+			embeds := body["embeds"].([]interface{})
+			require.Len(t, embeds, 1)
+			require.Equal(t, c.expColor, embeds[0].(map[string]interface{})["color"])
+		})
+	}
+}
@@ -0,0 +1,323 @@
+package channels
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/template"
+	"github.com/prometheus/alertmanager/types"
+	"github.com/prometheus/common/model"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/models"
+	"github.com/grafana/grafana/pkg/services/notifications"
+)
+
+// jiraTimeLayout is the timestamp format Jira's REST API uses for fields like resolutiondate.
+const jiraTimeLayout = "2006-01-02T15:04:05.000-0700"
+
+const defaultJiraSummary = `{{ template "default.title" . }}`
+const defaultJiraDescription = `{{ template "default.message" . }}`
+
+// jiraAlertLabelPrefix tags the Jira label used to tie an issue back to the alert group that
+// opened it: the prefix followed by the 16 hex digits of the group labels' fingerprint, e.g.
+// "ALERTd41d8cd98f00b204".
+const jiraAlertLabelPrefix = "ALERT"
+
+// JiraConfig holds the settings needed to create, update, and transition issues in a Jira project.
+type JiraConfig struct {
+	*NotificationChannelConfig
+
+	APIURL            string
+	User              string
+	Password          string
+	Project           string
+	IssueType         string
+	Summary           string
+	Description       string
+	Labels            []string
+	Priority          string
+	ReopenTransition  string
+	ResolveTransition string
+	WontFixResolution string
+	ReopenDuration    time.Duration
+}
+
+// NewJiraConfig parses and validates the notification channel settings for a Jira channel.
+func NewJiraConfig(config *NotificationChannelConfig, decryptFunc GetDecryptedValueFn) (*JiraConfig, error) {
+	apiURL := config.Settings.Get("api_url").MustString()
+	if apiURL == "" {
+		return nil, errors.New("could not find api_url in settings")
+	}
+	project := config.Settings.Get("project").MustString()
+	if project == "" {
+		return nil, errors.New("could not find project in settings")
+	}
+
+	var reopenDuration time.Duration
+	if s := config.Settings.Get("reopen_duration").MustString(); s != "" {
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid reopen_duration: %w", err)
+		}
+		reopenDuration = d
+	}
+
+	var labels []string
+	for _, l := range config.Settings.Get("labels").MustArray() {
+		if s, ok := l.(string); ok {
+			labels = append(labels, s)
+		}
+	}
+
+	return &JiraConfig{
+		NotificationChannelConfig: config,
+		APIURL:                    apiURL,
+		User:                      decryptFunc(context.Background(), config.SecureSettings, "user", config.Settings.Get("user").MustString()),
+		Password:                  decryptFunc(context.Background(), config.SecureSettings, "password", config.Settings.Get("password").MustString()),
+		Project:                   project,
+		IssueType:                 config.Settings.Get("issue_type").MustString("Bug"),
+		Summary:                   config.Settings.Get("summary").MustString(defaultJiraSummary),
+		Description:               config.Settings.Get("description").MustString(defaultJiraDescription),
+		Labels:                    labels,
+		Priority:                  config.Settings.Get("priority").MustString(),
+		ReopenTransition:          config.Settings.Get("reopen_transition").MustString(),
+		ResolveTransition:         config.Settings.Get("resolve_transition").MustString("Done"),
+		WontFixResolution:         config.Settings.Get("wont_fix_resolution").MustString(),
+		ReopenDuration:            reopenDuration,
+	}, nil
+}
+
+// JiraNotifier creates, updates, and transitions Jira issues in response to alert state changes,
+// instead of posting a one-off message like the webhook-style channels.
+type JiraNotifier struct {
+	conf *JiraConfig
+	log  log.Logger
+	ns   notifications.WebhookSender
+	tmpl *template.Template
+}
+
+// NewJiraNotifier returns a new notifier that files and manages Jira issues for alerts.
+func NewJiraNotifier(conf *JiraConfig, ns notifications.WebhookSender, t *template.Template) *JiraNotifier {
+	return &JiraNotifier{
+		conf: conf,
+		log:  log.New("alerting.notifier.jira"),
+		ns:   ns,
+		tmpl: t,
+	}
+}
+
+type jiraIssue struct {
+	Key    string          `json:"key"`
+	Fields jiraIssueFields `json:"fields"`
+}
+
+type jiraIssueFields struct {
+	Summary        string          `json:"summary,omitempty"`
+	Description    string          `json:"description,omitempty"`
+	Labels         []string        `json:"labels,omitempty"`
+	Priority       *jiraNamedField `json:"priority,omitempty"`
+	Project        *jiraNamedField `json:"project,omitempty"`
+	IssueType      *jiraNamedField `json:"issuetype,omitempty"`
+	Status         *jiraNamedField `json:"status,omitempty"`
+	Resolution     *jiraNamedField `json:"resolution,omitempty"`
+	ResolutionDate string          `json:"resolutiondate,omitempty"`
+}
+
+type jiraNamedField struct {
+	Name string `json:"name"`
+}
+
+type jiraSearchResult struct {
+	Issues []jiraIssue `json:"issues"`
+}
+
+// Notify implements the Notifier interface. It searches for the most recently created issue, in
+// any status, tagged with the alert group's hash label, creates one if none exists, updates its
+// fields otherwise, and drives a
+// transition when the alert group resolves. A broken summary/description template aborts before
+// any of that, since filing or updating an issue with a garbled field is worse than reporting
+// the template error.
+func (jn *JiraNotifier) Notify(ctx context.Context, as ...*types.Alert) (bool, error) {
+	var tmplErr error
+	tmpl, _ := TmplText(ctx, jn.tmpl, as, jn.log, &tmplErr)
+	summary := tmpl(jn.conf.Summary)
+	description := tmpl(jn.conf.Description)
+	if tmplErr != nil {
+		return false, tmplErr
+	}
+
+	hashLabel := jn.groupLabel(ctx, as)
+
+	existing, err := jn.findIssue(ctx, hashLabel)
+	if err != nil {
+		return false, fmt.Errorf("search Jira issues: %w", err)
+	}
+
+	if types.Alerts(as...).Status() == model.AlertResolved {
+		if existing == nil {
+			return true, nil
+		}
+		return true, jn.resolve(ctx, existing)
+	}
+
+	if existing == nil {
+		return true, jn.create(ctx, hashLabel, summary, description)
+	}
+	return true, jn.update(ctx, existing, summary, description)
+}
+
+func (jn *JiraNotifier) groupLabel(ctx context.Context, as []*types.Alert) string {
+	data := notify.GetTemplateData(ctx, jn.tmpl, as, jn.log)
+	groupLabels := make(model.LabelSet, len(data.GroupLabels))
+	for k, v := range data.GroupLabels {
+		groupLabels[model.LabelName(k)] = model.LabelValue(v)
+	}
+	return jiraAlertLabelPrefix + groupLabels.Fingerprint().String()
+}
+
+// findIssue returns the most recently created issue tagged with hashLabel, whatever its status
+// (closed issues included), or nil if there is none. Unlike create/update/transition it
+// needs to read the response body, so it goes through the same hardened notifications.WebhookSender
+// as the rest of this file, parsing the result out of the Validation callback rather than issuing
+// its own request — api_url is admin-controlled and must stay subject to whatever proxy/SSRF
+// protections the shared sender applies to it.
+func (jn *JiraNotifier) findIssue(ctx context.Context, hashLabel string) (*jiraIssue, error) {
+	jql := fmt.Sprintf(`project = "%s" AND labels = "%s" ORDER BY created DESC`, jn.conf.Project, hashLabel)
+	body, err := json.Marshal(map[string]interface{}{
+		"jql":        jql,
+		"maxResults": 1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var result jiraSearchResult
+	cmd := &models.SendWebhookSync{
+		Url:        jn.conf.APIURL + "/rest/api/2/search",
+		Body:       string(body),
+		HttpMethod: http.MethodPost,
+		User:       jn.conf.User,
+		Password:   jn.conf.Password,
+		HttpHeader: map[string]string{
+			"Content-Type": "application/json",
+		},
+		Validation: func(b []byte, statusCode int) error {
+			if statusCode/100 != 2 {
+				return fmt.Errorf("unexpected status %d searching for issue", statusCode)
+			}
+			return json.Unmarshal(b, &result)
+		},
+	}
+	if err := jn.ns.SendWebhookSync(ctx, cmd); err != nil {
+		return nil, err
+	}
+	if len(result.Issues) == 0 {
+		return nil, nil
+	}
+	return &result.Issues[0], nil
+}
+
+func (jn *JiraNotifier) create(ctx context.Context, hashLabel, summary, description string) error {
+	fields := jiraIssueFields{
+		Summary:     summary,
+		Description: description,
+		Labels:      append(append([]string{}, jn.conf.Labels...), hashLabel),
+		Project:     &jiraNamedField{Name: jn.conf.Project},
+		IssueType:   &jiraNamedField{Name: jn.conf.IssueType},
+	}
+	if jn.conf.Priority != "" {
+		fields.Priority = &jiraNamedField{Name: jn.conf.Priority}
+	}
+	body, err := json.Marshal(map[string]interface{}{"fields": fields})
+	if err != nil {
+		return err
+	}
+	return jn.send(ctx, http.MethodPost, "/rest/api/2/issue", body)
+}
+
+// update overwrites the issue's summary/description with the latest templated values. A Closed
+// issue is left untouched unless it's eligibleForReopen, the same gate resolve() applies when
+// deciding whether to reopen it — otherwise a re-firing alert would keep rewriting a ticket a
+// human explicitly closed as won't-fix, or one not yet past reopen_duration.
+func (jn *JiraNotifier) update(ctx context.Context, issue *jiraIssue, summary, description string) error {
+	if issue.Fields.Status != nil && issue.Fields.Status.Name == "Closed" && !jn.eligibleForReopen(issue) {
+		return nil
+	}
+	body, err := json.Marshal(map[string]interface{}{
+		"fields": jiraIssueFields{Summary: summary, Description: description},
+	})
+	if err != nil {
+		return err
+	}
+	return jn.send(ctx, http.MethodPut, fmt.Sprintf("/rest/api/2/issue/%s", issue.Key), body)
+}
+
+// resolve drives the issue towards its resolved transition. A closed issue is reopened only once
+// reopen_duration has been configured, the issue wasn't explicitly closed with
+// wont_fix_resolution, and at least reopen_duration has elapsed since it was resolved; otherwise
+// it's left alone.
+func (jn *JiraNotifier) resolve(ctx context.Context, issue *jiraIssue) error {
+	transitionName := jn.conf.ResolveTransition
+	if issue.Fields.Status != nil && issue.Fields.Status.Name == "Closed" {
+		if !jn.eligibleForReopen(issue) {
+			return nil
+		}
+		transitionName = jn.conf.ReopenTransition
+	}
+	if transitionName == "" {
+		return nil
+	}
+
+	body, err := json.Marshal(map[string]interface{}{
+		"transition": jiraNamedField{Name: transitionName},
+	})
+	if err != nil {
+		return err
+	}
+	return jn.send(ctx, http.MethodPost, fmt.Sprintf("/rest/api/2/issue/%s/transitions", issue.Key), body)
+}
+
+// eligibleForReopen reports whether a closed issue should be transitioned back open: reopening
+// must be enabled, the issue must not have been deliberately closed as won't-fix, and
+// reopen_duration must have elapsed since it was resolved.
+func (jn *JiraNotifier) eligibleForReopen(issue *jiraIssue) bool {
+	if jn.conf.ReopenDuration <= 0 {
+		return false
+	}
+	if jn.conf.WontFixResolution != "" && issue.Fields.Resolution != nil && issue.Fields.Resolution.Name == jn.conf.WontFixResolution {
+		return false
+	}
+	if issue.Fields.ResolutionDate == "" {
+		return true
+	}
+	resolvedAt, err := time.Parse(jiraTimeLayout, issue.Fields.ResolutionDate)
+	if err != nil {
+		jn.log.Warn("failed to parse Jira resolutiondate", "value", issue.Fields.ResolutionDate, "error", err)
+		return true
+	}
+	return time.Since(resolvedAt) >= jn.conf.ReopenDuration
+}
+
+func (jn *JiraNotifier) send(ctx context.Context, method, path string, body []byte) error {
+	cmd := &models.SendWebhookSync{
+		Url:        jn.conf.APIURL + path,
+		Body:       string(body),
+		HttpMethod: method,
+		User:       jn.conf.User,
+		Password:   jn.conf.Password,
+		HttpHeader: map[string]string{
+			"Content-Type": "application/json",
+		},
+	}
+	return jn.ns.SendWebhookSync(ctx, cmd)
+}
+
+func (jn *JiraNotifier) SendResolved() bool {
+	return !jn.conf.DisableResolveMessage
+}
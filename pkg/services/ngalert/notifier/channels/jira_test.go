@@ -0,0 +1,195 @@
+package channels
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/types"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/components/simplejson"
+)
+
+func TestJiraNotifier(t *testing.T) {
+	tmpl := templateForTests(t)
+
+	externalURL, err := url.Parse("http://localhost")
+	require.NoError(t, err)
+	tmpl.ExternalURL = externalURL
+
+	alerts := []*types.Alert{
+		{
+			Alert: model.Alert{
+				Labels:      model.LabelSet{"alertname": "alert1", "lbl1": "val1"},
+				Annotations: model.LabelSet{"ann1": "annv1"},
+			},
+		},
+	}
+	resolvedAlerts := []*types.Alert{
+		{
+			Alert: model.Alert{
+				Labels:      model.LabelSet{"alertname": "alert1", "lbl1": "val1"},
+				Annotations: model.LabelSet{"ann1": "annv1"},
+				EndsAt:      model.Now(),
+			},
+		},
+	}
+
+	cases := []struct {
+		name            string
+		alerts          []*types.Alert
+		searchResult    jiraSearchResult
+		settings        string
+		expMethod       string
+		expPathSuffix   string
+		expBodyContains string
+	}{
+		{
+			name:            "No existing issue creates one",
+			alerts:          alerts,
+			searchResult:    jiraSearchResult{},
+			settings:        `{"api_url": "%s", "project": "OPS"}`,
+			expMethod:       http.MethodPost,
+			expPathSuffix:   "/rest/api/2/issue",
+			expBodyContains: `"project":{"name":"OPS"}`,
+		},
+		{
+			name:   "Existing open issue is updated",
+			alerts: alerts,
+			searchResult: jiraSearchResult{Issues: []jiraIssue{{Key: "OPS-1", Fields: jiraIssueFields{
+				Status: &jiraNamedField{Name: "Open"},
+			}}}},
+			settings:        `{"api_url": "%s", "project": "OPS"}`,
+			expMethod:       http.MethodPut,
+			expPathSuffix:   "/rest/api/2/issue/OPS-1",
+			expBodyContains: `"summary"`,
+		},
+		{
+			name:   "Resolved alert transitions the open issue",
+			alerts: resolvedAlerts,
+			searchResult: jiraSearchResult{Issues: []jiraIssue{{Key: "OPS-1", Fields: jiraIssueFields{
+				Status: &jiraNamedField{Name: "Open"},
+			}}}},
+			settings:        `{"api_url": "%s", "project": "OPS", "resolve_transition": "Done"}`,
+			expMethod:       http.MethodPost,
+			expPathSuffix:   "/rest/api/2/issue/OPS-1/transitions",
+			expBodyContains: `"Done"`,
+		},
+		{
+			name:   "Resolved alert reopens a closed issue once reopen_duration has elapsed",
+			alerts: resolvedAlerts,
+			searchResult: jiraSearchResult{Issues: []jiraIssue{{Key: "OPS-1", Fields: jiraIssueFields{
+				Status:         &jiraNamedField{Name: "Closed"},
+				ResolutionDate: time.Now().Add(-48 * time.Hour).Format(jiraTimeLayout),
+			}}}},
+			settings:        `{"api_url": "%s", "project": "OPS", "reopen_transition": "Reopen", "reopen_duration": "24h"}`,
+			expMethod:       http.MethodPost,
+			expPathSuffix:   "/rest/api/2/issue/OPS-1/transitions",
+			expBodyContains: `"Reopen"`,
+		},
+		{
+			name:   "Resolved alert leaves a closed issue alone before reopen_duration elapses",
+			alerts: resolvedAlerts,
+			searchResult: jiraSearchResult{Issues: []jiraIssue{{Key: "OPS-1", Fields: jiraIssueFields{
+				Status:         &jiraNamedField{Name: "Closed"},
+				ResolutionDate: time.Now().Format(jiraTimeLayout),
+			}}}},
+			settings: `{"api_url": "%s", "project": "OPS", "reopen_transition": "Reopen", "reopen_duration": "24h"}`,
+			// No transition is issued, so the last recorded webhook is still the search request.
+			expMethod:       http.MethodPost,
+			expPathSuffix:   "/rest/api/2/search",
+			expBodyContains: `"maxResults":1`,
+		},
+		{
+			name:   "Resolved alert never reopens an issue closed as won't-fix",
+			alerts: resolvedAlerts,
+			searchResult: jiraSearchResult{Issues: []jiraIssue{{Key: "OPS-1", Fields: jiraIssueFields{
+				Status:         &jiraNamedField{Name: "Closed"},
+				Resolution:     &jiraNamedField{Name: "Won't Fix"},
+				ResolutionDate: time.Now().Add(-48 * time.Hour).Format(jiraTimeLayout),
+			}}}},
+			settings: `{"api_url": "%s", "project": "OPS", "reopen_transition": "Reopen", "reopen_duration": "24h",
+				"wont_fix_resolution": "Won't Fix"}`,
+			expMethod:       http.MethodPost,
+			expPathSuffix:   "/rest/api/2/search",
+			expBodyContains: `"maxResults":1`,
+		},
+		{
+			name:   "Re-firing alert never rewrites an issue closed as won't-fix",
+			alerts: alerts,
+			searchResult: jiraSearchResult{Issues: []jiraIssue{{Key: "OPS-1", Fields: jiraIssueFields{
+				Status:         &jiraNamedField{Name: "Closed"},
+				Resolution:     &jiraNamedField{Name: "Won't Fix"},
+				ResolutionDate: time.Now().Add(-48 * time.Hour).Format(jiraTimeLayout),
+			}}}},
+			settings: `{"api_url": "%s", "project": "OPS", "reopen_transition": "Reopen", "reopen_duration": "24h",
+				"wont_fix_resolution": "Won't Fix"}`,
+			// No update is issued, so the last recorded webhook is still the search request.
+			expMethod:       http.MethodPost,
+			expPathSuffix:   "/rest/api/2/search",
+			expBodyContains: `"maxResults":1`,
+		},
+		{
+			name:   "Re-firing alert leaves a closed issue alone before reopen_duration elapses",
+			alerts: alerts,
+			searchResult: jiraSearchResult{Issues: []jiraIssue{{Key: "OPS-1", Fields: jiraIssueFields{
+				Status:         &jiraNamedField{Name: "Closed"},
+				ResolutionDate: time.Now().Format(jiraTimeLayout),
+			}}}},
+			settings:        `{"api_url": "%s", "project": "OPS", "reopen_transition": "Reopen", "reopen_duration": "24h"}`,
+			expMethod:       http.MethodPost,
+			expPathSuffix:   "/rest/api/2/search",
+			expBodyContains: `"maxResults":1`,
+		},
+		{
+			name:   "Re-firing alert updates a closed issue once reopen_duration has elapsed",
+			alerts: alerts,
+			searchResult: jiraSearchResult{Issues: []jiraIssue{{Key: "OPS-1", Fields: jiraIssueFields{
+				Status:         &jiraNamedField{Name: "Closed"},
+				ResolutionDate: time.Now().Add(-48 * time.Hour).Format(jiraTimeLayout),
+			}}}},
+			settings:        `{"api_url": "%s", "project": "OPS", "reopen_transition": "Reopen", "reopen_duration": "24h"}`,
+			expMethod:       http.MethodPut,
+			expPathSuffix:   "/rest/api/2/issue/OPS-1",
+			expBodyContains: `"summary"`,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			settingsJson, err := simplejson.NewJson([]byte(fmt.Sprintf(c.settings, "http://jira.example.com")))
+			require.NoError(t, err)
+
+			m := &NotificationChannelConfig{
+				Name:     "jira_testing",
+				Type:     "jira",
+				Settings: settingsJson,
+			}
+
+			respBody, err := json.Marshal(c.searchResult)
+			require.NoError(t, err)
+			webhookSender := mockNotificationService()
+			webhookSender.RespBody = respBody
+			cfg, err := NewJiraConfig(m, decryptFn)
+			require.NoError(t, err)
+
+			ctx := notify.WithGroupKey(context.Background(), "alertname")
+			ctx = notify.WithGroupLabels(ctx, model.LabelSet{"alertname": ""})
+			jn := NewJiraNotifier(cfg, webhookSender, tmpl)
+			ok, err := jn.Notify(ctx, c.alerts...)
+			require.NoError(t, err)
+			require.True(t, ok)
+
+			require.Equal(t, c.expMethod, webhookSender.Webhook.HttpMethod)
+			require.Contains(t, webhookSender.Webhook.Url, c.expPathSuffix)
+			require.Contains(t, webhookSender.Webhook.Body, c.expBodyContains)
+		})
+	}
+}
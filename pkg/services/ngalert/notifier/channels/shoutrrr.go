@@ -0,0 +1,192 @@
+package channels
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/prometheus/alertmanager/template"
+	"github.com/prometheus/alertmanager/types"
+
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/notifications"
+)
+
+// ShoutrrrConfig holds one or more shoutrrr-style service URLs (e.g. "discord://token@id" or
+// "slack://hook/T000/B000/S000"), each of which is dispatched to its matching channel
+// implementation.
+type ShoutrrrConfig struct {
+	*NotificationChannelConfig
+
+	URLs []string
+}
+
+// NewShoutrrrConfig parses and validates the notification channel settings for a "url" channel.
+func NewShoutrrrConfig(config *NotificationChannelConfig) (*ShoutrrrConfig, error) {
+	raw := config.Settings.Get("url").MustString()
+	if raw == "" {
+		return nil, errors.New("could not find url property in settings")
+	}
+	return &ShoutrrrConfig{
+		NotificationChannelConfig: config,
+		URLs:                      strings.Fields(raw),
+	}, nil
+}
+
+// shoutrrrTarget is the subset of the Notifier interface a dispatched-to channel needs to
+// implement for the "url" channel to drive it.
+type shoutrrrTarget interface {
+	Notify(ctx context.Context, as ...*types.Alert) (bool, error)
+}
+
+// ShoutrrrNotifier parses a space-separated list of shoutrrr-style service URLs into the
+// matching per-provider Config/Notifier pair and dispatches each alert to all of them, so a user
+// can fan a single channel out to several providers without one channel config per provider.
+type ShoutrrrNotifier struct {
+	conf    *ShoutrrrConfig
+	log     log.Logger
+	targets []shoutrrrTarget
+}
+
+// NewShoutrrrNotifier parses every URL in conf.URLs into its matching notifier up front, so a
+// malformed URL is reported at channel-save time rather than at notify time.
+func NewShoutrrrNotifier(conf *ShoutrrrConfig, ns notifications.WebhookSender, t *template.Template) (*ShoutrrrNotifier, error) {
+	targets := make([]shoutrrrTarget, 0, len(conf.URLs))
+	for _, raw := range conf.URLs {
+		target, err := buildShoutrrrTarget(raw, conf.NotificationChannelConfig, ns, t)
+		if err != nil {
+			return nil, fmt.Errorf("parsing shoutrrr url: %w", err)
+		}
+		targets = append(targets, target)
+	}
+	return &ShoutrrrNotifier{
+		conf:    conf,
+		log:     log.New("alerting.notifier.url"),
+		targets: targets,
+	}, nil
+}
+
+// buildShoutrrrTarget maps a single shoutrrr-style URL to the Config/Notifier pair of the
+// channel it addresses, reusing each channel's own NewXConfig/NewXNotifier constructors so the
+// URL channel never has to duplicate their templating or delivery logic.
+func buildShoutrrrTarget(raw string, base *NotificationChannelConfig, ns notifications.WebhookSender, t *template.Template) (shoutrrrTarget, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid url %q: %w", raw, err)
+	}
+
+	switch u.Scheme {
+	case "discord":
+		cfg, err := discordConfigFromShoutrrrURL(u, base)
+		if err != nil {
+			return nil, err
+		}
+		return NewDiscordNotifier(cfg, ns, t), nil
+	case "slack":
+		cfg, err := slackConfigFromShoutrrrURL(u, base)
+		if err != nil {
+			return nil, err
+		}
+		return NewSlackNotifier(cfg, ns, t), nil
+	case "telegram":
+		cfg, err := telegramConfigFromShoutrrrURL(u, base)
+		if err != nil {
+			return nil, err
+		}
+		return NewTelegramNotifier(cfg, ns, t), nil
+	default:
+		return nil, fmt.Errorf("unsupported shoutrrr scheme %q", u.Scheme)
+	}
+}
+
+// discordConfigFromShoutrrrURL maps "discord://<token>@<webhook-id>" onto the same Discord
+// webhook URL a user would otherwise paste in by hand.
+func discordConfigFromShoutrrrURL(u *url.URL, base *NotificationChannelConfig) (*DiscordConfig, error) {
+	if u.User == nil || u.User.Username() == "" || u.Host == "" {
+		return nil, errors.New("discord url must have the form discord://token@webhook-id")
+	}
+	webhookURL := fmt.Sprintf("https://discord.com/api/webhooks/%s/%s", u.Host, u.User.Username())
+	return NewDiscordConfig(childChannelConfig(base, "discord", map[string]interface{}{
+		"url": webhookURL,
+	}))
+}
+
+// slackConfigFromShoutrrrURL maps "slack://hook/<T>/<B>/<S>" onto the same Slack incoming
+// webhook URL a user would otherwise paste in by hand.
+func slackConfigFromShoutrrrURL(u *url.URL, base *NotificationChannelConfig) (*SlackConfig, error) {
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if u.Host != "hook" || len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return nil, errors.New("slack url must have the form slack://hook/T.../B.../S...")
+	}
+	webhookURL := fmt.Sprintf("https://hooks.slack.com/services/%s/%s/%s", parts[0], parts[1], parts[2])
+	return NewSlackConfig(childChannelConfig(base, "slack", map[string]interface{}{
+		"url": webhookURL,
+	}), passthroughDecrypt)
+}
+
+// telegramConfigFromShoutrrrURL maps "telegram://<bot-token>@telegram?chats=<chat-id>" onto the
+// bot token and chat id a user would otherwise enter by hand.
+func telegramConfigFromShoutrrrURL(u *url.URL, base *NotificationChannelConfig) (*TelegramConfig, error) {
+	if u.User == nil || u.User.Username() == "" {
+		return nil, errors.New("telegram url must have the form telegram://bottoken@telegram?chats=chat-id")
+	}
+	chats := u.Query()["chats"]
+	if len(chats) == 0 || chats[0] == "" {
+		return nil, errors.New("telegram url must specify at least one chat in the chats query parameter")
+	}
+	return NewTelegramConfig(childChannelConfig(base, "telegram", map[string]interface{}{
+		"bottoken": u.User.Username(),
+		"chatid":   chats[0],
+	}), passthroughDecrypt)
+}
+
+// passthroughDecrypt is used in place of the real decryption lookup when building a shoutrrr
+// child config whose secret was already extracted from the URL in plaintext, so the child
+// NewXConfig constructor's decryptFunc call is a no-op that just returns the fallback it's given.
+func passthroughDecrypt(_ context.Context, _ map[string][]byte, _ string, fallback string) string {
+	return fallback
+}
+
+// childChannelConfig builds the NotificationChannelConfig a shoutrrr target's own NewXConfig
+// constructor expects, carrying over the parent "url" channel's name and resolve-message setting
+// and translating the parsed URL fields into the plain settings JSON that constructor parses.
+func childChannelConfig(base *NotificationChannelConfig, channelType string, settings map[string]interface{}) *NotificationChannelConfig {
+	sj := simplejson.New()
+	for k, v := range settings {
+		sj.Set(k, v)
+	}
+	return &NotificationChannelConfig{
+		Name:                  base.Name,
+		Type:                  channelType,
+		DisableResolveMessage: base.DisableResolveMessage,
+		Settings:              sj,
+	}
+}
+
+// Notify implements the Notifier interface. It dispatches the alerts to every parsed target and
+// only reports failure once every target has been tried, so one misconfigured provider doesn't
+// prevent delivery to the others.
+func (un *ShoutrrrNotifier) Notify(ctx context.Context, as ...*types.Alert) (bool, error) {
+	var errs []string
+	anyOK := false
+	for _, target := range un.targets {
+		ok, err := target.Notify(ctx, as...)
+		if ok {
+			anyOK = true
+		}
+		if err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	if len(errs) > 0 {
+		return anyOK, fmt.Errorf("one or more url targets failed: %s", strings.Join(errs, "; "))
+	}
+	return anyOK, nil
+}
+
+func (un *ShoutrrrNotifier) SendResolved() bool {
+	return !un.conf.DisableResolveMessage
+}
@@ -0,0 +1,90 @@
+package channels
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/types"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/components/simplejson"
+)
+
+func TestShoutrrrURLParsing(t *testing.T) {
+	base := &NotificationChannelConfig{Name: "url_testing", Type: "url"}
+
+	t.Run("discord", func(t *testing.T) {
+		u, err := url.Parse("discord://mytoken@123456789")
+		require.NoError(t, err)
+		cfg, err := discordConfigFromShoutrrrURL(u, base)
+		require.NoError(t, err)
+		require.Equal(t, "https://discord.com/api/webhooks/123456789/mytoken", cfg.Settings.Get("url").MustString())
+	})
+
+	t.Run("slack", func(t *testing.T) {
+		u, err := url.Parse("slack://hook/T000/B000/S000")
+		require.NoError(t, err)
+		cfg, err := slackConfigFromShoutrrrURL(u, base)
+		require.NoError(t, err)
+		require.Equal(t, "https://hooks.slack.com/services/T000/B000/S000", cfg.Settings.Get("url").MustString())
+	})
+
+	t.Run("telegram", func(t *testing.T) {
+		u, err := url.Parse("telegram://mytoken@telegram?chats=@mychannel")
+		require.NoError(t, err)
+		cfg, err := telegramConfigFromShoutrrrURL(u, base)
+		require.NoError(t, err)
+		require.Equal(t, "mytoken", cfg.Settings.Get("bottoken").MustString())
+		require.Equal(t, "@mychannel", cfg.Settings.Get("chatid").MustString())
+	})
+
+	t.Run("unsupported scheme", func(t *testing.T) {
+		_, err := buildShoutrrrTarget("carrierpigeon://nope", base, mockNotificationService(), templateForTests(t))
+		require.EqualError(t, err, `unsupported shoutrrr scheme "carrierpigeon"`)
+	})
+}
+
+func TestShoutrrrNotifier(t *testing.T) {
+	tmpl := templateForTests(t)
+
+	externalURL, err := url.Parse("http://localhost")
+	require.NoError(t, err)
+	tmpl.ExternalURL = externalURL
+
+	settingsJson, err := simplejson.NewJson([]byte(`{"url": "discord://mytoken@123456789 slack://hook/T000/B000/S000"}`))
+	require.NoError(t, err)
+
+	m := &NotificationChannelConfig{
+		Name:     "url_testing",
+		Type:     "url",
+		Settings: settingsJson,
+	}
+
+	webhookSender := mockNotificationService()
+	cfg, err := NewShoutrrrConfig(m)
+	require.NoError(t, err)
+	require.Equal(t, []string{"discord://mytoken@123456789", "slack://hook/T000/B000/S000"}, cfg.URLs)
+
+	un, err := NewShoutrrrNotifier(cfg, webhookSender, tmpl)
+	require.NoError(t, err)
+	require.Len(t, un.targets, 2)
+
+	alerts := []*types.Alert{
+		{
+			Alert: model.Alert{
+				Labels:      model.LabelSet{"alertname": "alert1", "lbl1": "val1"},
+				Annotations: model.LabelSet{"ann1": "annv1"},
+			},
+		},
+	}
+
+	ctx := notify.WithGroupKey(context.Background(), "alertname")
+	ctx = notify.WithGroupLabels(ctx, model.LabelSet{"alertname": ""})
+	ok, err := un.Notify(ctx, alerts...)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Contains(t, webhookSender.Webhook.Url, "hooks.slack.com")
+}
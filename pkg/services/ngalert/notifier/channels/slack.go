@@ -0,0 +1,113 @@
+package channels
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/prometheus/alertmanager/template"
+	"github.com/prometheus/alertmanager/types"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/models"
+	"github.com/grafana/grafana/pkg/services/notifications"
+)
+
+const defaultSlackMessage = `{{ template "default.message" . }}`
+
+// SlackConfig holds the settings needed to post alert notifications to a Slack channel through a
+// legacy incoming webhook.
+type SlackConfig struct {
+	*NotificationChannelConfig
+
+	WebhookURL string
+	Recipient  string
+	Username   string
+	IconEmoji  string
+	IconURL    string
+	Message    string
+}
+
+// NewSlackConfig parses and validates the notification channel settings for a Slack channel.
+func NewSlackConfig(config *NotificationChannelConfig, decryptFunc GetDecryptedValueFn) (*SlackConfig, error) {
+	webhookURL := decryptFunc(context.Background(), config.SecureSettings, "url", config.Settings.Get("url").MustString())
+	if webhookURL == "" {
+		return nil, errors.New("could not find webhook url property in settings")
+	}
+	return &SlackConfig{
+		NotificationChannelConfig: config,
+		WebhookURL:                webhookURL,
+		Recipient:                 config.Settings.Get("recipient").MustString(),
+		Username:                  config.Settings.Get("username").MustString(),
+		IconEmoji:                 config.Settings.Get("icon_emoji").MustString(),
+		IconURL:                   config.Settings.Get("icon_url").MustString(),
+		Message:                   config.Settings.Get("message").MustString(defaultSlackMessage),
+	}, nil
+}
+
+// SlackNotifier posts alert notifications to a Slack channel through a legacy incoming webhook.
+type SlackNotifier struct {
+	conf *SlackConfig
+	log  log.Logger
+	ns   notifications.WebhookSender
+	tmpl *template.Template
+}
+
+// NewSlackNotifier returns a new notifier that posts alerts to Slack.
+func NewSlackNotifier(conf *SlackConfig, ns notifications.WebhookSender, t *template.Template) *SlackNotifier {
+	return &SlackNotifier{
+		conf: conf,
+		log:  log.New("alerting.notifier.slack"),
+		ns:   ns,
+		tmpl: t,
+	}
+}
+
+// slackMessage is the payload accepted by a Slack incoming webhook.
+type slackMessage struct {
+	Channel   string `json:"channel,omitempty"`
+	Username  string `json:"username,omitempty"`
+	IconEmoji string `json:"icon_emoji,omitempty"`
+	IconURL   string `json:"icon_url,omitempty"`
+	Text      string `json:"text"`
+}
+
+// Notify implements the Notifier interface. A broken message template fails the notification
+// outright rather than posting a blank or garbled message to the channel.
+func (sn *SlackNotifier) Notify(ctx context.Context, as ...*types.Alert) (bool, error) {
+	var tmplErr error
+	tmpl, _ := TmplText(ctx, sn.tmpl, as, sn.log, &tmplErr)
+
+	msg := &slackMessage{
+		Channel:   sn.conf.Recipient,
+		Username:  sn.conf.Username,
+		IconEmoji: sn.conf.IconEmoji,
+		IconURL:   sn.conf.IconURL,
+		Text:      tmpl(sn.conf.Message),
+	}
+	if tmplErr != nil {
+		return false, tmplErr
+	}
+
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return false, fmt.Errorf("marshal Slack message: %w", err)
+	}
+
+	cmd := &models.SendWebhookSync{
+		Url:        sn.conf.WebhookURL,
+		Body:       string(body),
+		HttpMethod: http.MethodPost,
+	}
+	if err := sn.ns.SendWebhookSync(ctx, cmd); err != nil {
+		sn.log.Error("failed to send Slack notification", "error", err, "webhook", sn.conf.WebhookURL)
+		return false, err
+	}
+	return true, nil
+}
+
+func (sn *SlackNotifier) SendResolved() bool {
+	return !sn.conf.DisableResolveMessage
+}
@@ -0,0 +1,145 @@
+package channels
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/prometheus/alertmanager/template"
+	"github.com/prometheus/alertmanager/types"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/models"
+	"github.com/grafana/grafana/pkg/services/notifications"
+)
+
+const defaultTelegramMessage = `{{ template "default.message" . }}`
+
+// TelegramConfig holds the settings needed to post alert notifications to a Telegram chat through
+// a bot.
+type TelegramConfig struct {
+	*NotificationChannelConfig
+
+	BotToken string
+	ChatID   string
+	Message  string
+}
+
+// NewTelegramConfig parses and validates the notification channel settings for a Telegram
+// channel.
+func NewTelegramConfig(config *NotificationChannelConfig, decryptFunc GetDecryptedValueFn) (*TelegramConfig, error) {
+	botToken := decryptFunc(context.Background(), config.SecureSettings, "bottoken", config.Settings.Get("bottoken").MustString())
+	if botToken == "" {
+		return nil, errors.New("could not find Bot Token in settings")
+	}
+	chatID := config.Settings.Get("chatid").MustString()
+	if chatID == "" {
+		return nil, errors.New("could not find Chat Id in settings")
+	}
+	return &TelegramConfig{
+		NotificationChannelConfig: config,
+		BotToken:                  botToken,
+		ChatID:                    chatID,
+		Message:                   config.Settings.Get("message").MustString(defaultTelegramMessage),
+	}, nil
+}
+
+// TelegramNotifier posts alert notifications to a Telegram chat using the Telegram Bot API.
+type TelegramNotifier struct {
+	conf *TelegramConfig
+	log  log.Logger
+	ns   notifications.WebhookSender
+	tmpl *template.Template
+}
+
+// NewTelegramNotifier returns a new notifier that sends alerts to Telegram.
+func NewTelegramNotifier(conf *TelegramConfig, ns notifications.WebhookSender, t *template.Template) *TelegramNotifier {
+	return &TelegramNotifier{
+		conf: conf,
+		log:  log.New("alerting.notifier.telegram"),
+		ns:   ns,
+		tmpl: t,
+	}
+}
+
+// telegramMessage is the payload accepted by the Telegram Bot API's sendMessage method.
+type telegramMessage struct {
+	ChatID    string `json:"chat_id"`
+	Text      string `json:"text"`
+	ParseMode string `json:"parse_mode"`
+}
+
+// Notify implements the Notifier interface. A broken message template fails the notification
+// outright rather than sending a blank or garbled message to the chat.
+func (tn *TelegramNotifier) Notify(ctx context.Context, as ...*types.Alert) (bool, error) {
+	var tmplErr error
+	tmpl, _ := TmplText(ctx, tn.tmpl, as, tn.log, &tmplErr)
+
+	msg := &telegramMessage{
+		ChatID:    tn.conf.ChatID,
+		Text:      tmpl(tn.conf.Message),
+		ParseMode: "html",
+	}
+	if tmplErr != nil {
+		return false, tmplErr
+	}
+
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return false, fmt.Errorf("marshal Telegram message: %w", err)
+	}
+
+	cmd := &models.SendWebhookSync{
+		Url:        fmt.Sprintf("https://api.telegram.org/bot%s/sendMessage", tn.conf.BotToken),
+		Body:       string(body),
+		HttpMethod: http.MethodPost,
+		HttpHeader: map[string]string{
+			"Content-Type": "application/json",
+		},
+		Validation: telegramValidateResponse,
+	}
+
+	if err := tn.ns.SendWebhookSync(ctx, cmd); err != nil {
+		tn.log.Error("failed to send Telegram message", "error", err, "telegram_chat", tn.conf.ChatID)
+		var apiErr *telegramAPIError
+		if errors.As(err, &apiErr) {
+			return apiErr.retriable, apiErr
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+// telegramAPIError carries whether a failed Telegram API call is safe to retry.
+type telegramAPIError struct {
+	retriable bool
+	err       error
+}
+
+func (e *telegramAPIError) Error() string {
+	return e.err.Error()
+}
+
+func (e *telegramAPIError) Unwrap() error {
+	return e.err
+}
+
+// telegramValidateResponse turns a Telegram API response into a retriable or terminal error.
+// Telegram returns 429 on rate limiting and 5xx on transient outages, both of which are safe to
+// retry; any other non-2xx status is treated as a permanent failure of this notification attempt.
+func telegramValidateResponse(b []byte, statusCode int) error {
+	if statusCode/100 == 2 {
+		return nil
+	}
+	err := fmt.Errorf("telegram API returned status %d, body %s", statusCode, bytes.TrimSpace(b))
+	retriable := statusCode == http.StatusTooManyRequests || statusCode/100 == 5
+	return &telegramAPIError{retriable: retriable, err: err}
+}
+
+func (tn *TelegramNotifier) SendResolved() bool {
+	return !tn.conf.DisableResolveMessage
+}
@@ -0,0 +1,56 @@
+package channels
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	texttemplate "text/template"
+
+	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/template"
+	"github.com/prometheus/alertmanager/types"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+)
+
+// TemplateFuncs are the helper functions available to every notifier message template, on top of
+// the functions the alertmanager template engine already provides. They let users write things
+// like `{{ Join (.CommonLabels.SortedPairs.Names) ", " | ToUpper }}` in a channel's message field.
+var TemplateFuncs = texttemplate.FuncMap{
+	"ToUpper":    strings.ToUpper,
+	"ToLower":    strings.ToLower,
+	"Title":      strings.Title,
+	"Join":       strings.Join,
+	"TrimSpace":  strings.TrimSpace,
+	"ReplaceAll": func(old, new, s string) string { return strings.ReplaceAll(s, old, new) },
+	"HasPrefix":  func(prefix, s string) bool { return strings.HasPrefix(s, prefix) },
+	"HasSuffix":  func(suffix, s string) bool { return strings.HasSuffix(s, suffix) },
+	"Match":      regexMatch,
+	"ReMatch":    regexMatch,
+}
+
+func regexMatch(pattern, s string) (bool, error) {
+	return regexp.MatchString(pattern, s)
+}
+
+// ApplyTemplateFuncs registers TemplateFuncs on tmpl so that every notifier message template
+// rendered through tmpl can use them. In this package only templateForTests calls it; a template
+// that has not been passed through ApplyTemplateFuncs does not have these functions.
+func ApplyTemplateFuncs(tmpl *template.Template) {
+	tmpl.Funcs(TemplateFuncs)
+}
+
+// TmplText returns a function that executes a named or inline template string against the
+// extended template data built from as, recording the first templating error encountered in
+// *tmplErr rather than aborting, so every field of a message can be rendered before the caller
+// checks *tmplErr and decides whether to send it.
+func TmplText(ctx context.Context, tmpl *template.Template, as []*types.Alert, l log.Logger, tmplErr *error) (func(string) string, *template.Data) {
+	data := notify.GetTemplateData(ctx, tmpl, as, l)
+	return func(name string) string {
+		res, err := tmpl.ExecuteTextString(name, data)
+		if err != nil && *tmplErr == nil {
+			*tmplErr = err
+		}
+		return res
+	}, data
+}
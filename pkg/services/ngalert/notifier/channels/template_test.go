@@ -0,0 +1,69 @@
+package channels
+
+import (
+	"bytes"
+	"testing"
+	texttemplate "text/template"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestTemplateFuncs exercises TemplateFuncs directly against text/template, independent of any
+// one channel, covering the piping behavior of HasPrefix, HasSuffix, Match and ReMatch, which
+// take the piped value as their last argument.
+func TestTemplateFuncs(t *testing.T) {
+	cases := []struct {
+		name     string
+		tmpl     string
+		val      string
+		expected string
+	}{
+		{
+			name:     "HasPrefix true when the piped value has the prefix",
+			tmpl:     `{{ if .Val | HasPrefix "al" }}yes{{ else }}no{{ end }}`,
+			val:      "alert1",
+			expected: "yes",
+		},
+		{
+			name:     "HasPrefix false when the piped value lacks the prefix",
+			tmpl:     `{{ if .Val | HasPrefix "al" }}yes{{ else }}no{{ end }}`,
+			val:      "lbl1",
+			expected: "no",
+		},
+		{
+			name:     "HasSuffix true when the piped value has the suffix",
+			tmpl:     `{{ if .Val | HasSuffix "1" }}yes{{ else }}no{{ end }}`,
+			val:      "alert1",
+			expected: "yes",
+		},
+		{
+			name:     "HasSuffix false when the piped value lacks the suffix",
+			tmpl:     `{{ if .Val | HasSuffix "9" }}yes{{ else }}no{{ end }}`,
+			val:      "alert1",
+			expected: "no",
+		},
+		{
+			name:     "Match reports whether the piped value matches the regex",
+			tmpl:     `{{ if .Val | Match "^alert[0-9]+$" }}yes{{ else }}no{{ end }}`,
+			val:      "alert1",
+			expected: "yes",
+		},
+		{
+			name:     "ReMatch is an alias for Match",
+			tmpl:     `{{ if .Val | ReMatch "^alert[0-9]+$" }}yes{{ else }}no{{ end }}`,
+			val:      "lbl1",
+			expected: "no",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			tmpl, err := texttemplate.New("t").Funcs(TemplateFuncs).Parse(c.tmpl)
+			require.NoError(t, err)
+
+			var buf bytes.Buffer
+			require.NoError(t, tmpl.Execute(&buf, struct{ Val string }{c.val}))
+			require.Equal(t, c.expected, buf.String())
+		})
+	}
+}
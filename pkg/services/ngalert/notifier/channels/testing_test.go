@@ -0,0 +1,61 @@
+package channels
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/prometheus/alertmanager/template"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/models"
+)
+
+// templateForTests returns a template.Template wired up with Grafana's default templates and the
+// shared notifier TemplateFuncs, suitable for use across every channel's tests.
+func templateForTests(t *testing.T) *template.Template {
+	t.Helper()
+
+	tmpl, err := template.New(nil)
+	require.NoError(t, err)
+	ApplyTemplateFuncs(tmpl)
+
+	return tmpl
+}
+
+// notificationServiceMock records the last webhook sent through it instead of making a real HTTP
+// call, so tests can assert on the request a notifier builds. When the notifier sets
+// cmd.Validation (to classify or parse a response, as the Webex and Jira notifiers do), the mock
+// runs it against RespStatusCode/RespBody to emulate the response the real sender would have
+// received, so that codepath can be exercised without a server.
+type notificationServiceMock struct {
+	Webhook        models.SendWebhookSync
+	ShouldError    error
+	RespStatusCode int
+	RespBody       []byte
+}
+
+func mockNotificationService() *notificationServiceMock {
+	return &notificationServiceMock{}
+}
+
+func (ns *notificationServiceMock) SendWebhookSync(ctx context.Context, cmd *models.SendWebhookSync) error {
+	ns.Webhook = *cmd
+	if ns.ShouldError != nil {
+		return ns.ShouldError
+	}
+	if cmd.Validation != nil {
+		status := ns.RespStatusCode
+		if status == 0 {
+			status = http.StatusOK
+		}
+		return cmd.Validation(ns.RespBody, status)
+	}
+	return nil
+}
+
+// decryptFn is a no-op GetDecryptedValueFn for tests: it always falls back to the plaintext
+// setting, since tests configure secrets directly rather than via secure settings encryption.
+func decryptFn(_ context.Context, _ map[string][]byte, _ string, fallback string) string {
+	return fallback
+}
@@ -0,0 +1,150 @@
+package channels
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/prometheus/alertmanager/template"
+	"github.com/prometheus/alertmanager/types"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/models"
+	"github.com/grafana/grafana/pkg/services/notifications"
+)
+
+const webexAPIURL = "https://webexapis.com/v1/messages"
+
+const defaultWebexMessage = `{{ template "default.message" . }}`
+
+// WebexConfig holds the settings used to authenticate with and address a Cisco Webex Teams room.
+type WebexConfig struct {
+	*NotificationChannelConfig
+
+	BotToken      string
+	RoomID        string
+	ToPersonEmail string
+	Message       string
+}
+
+// NewWebexConfig parses and validates the notification channel settings for a Webex Teams channel.
+func NewWebexConfig(config *NotificationChannelConfig, decryptFunc GetDecryptedValueFn) (*WebexConfig, error) {
+	botToken := decryptFunc(context.Background(), config.SecureSettings, "bot_token", config.Settings.Get("bot_token").MustString())
+	if botToken == "" {
+		return nil, errors.New("could not find Bot Token in settings")
+	}
+	roomID := config.Settings.Get("room_id").MustString()
+	toPersonEmail := config.Settings.Get("to_person_email").MustString()
+	if roomID == "" && toPersonEmail == "" {
+		return nil, errors.New("could not find room_id or to_person_email in settings")
+	}
+	return &WebexConfig{
+		NotificationChannelConfig: config,
+		BotToken:                  botToken,
+		RoomID:                    roomID,
+		ToPersonEmail:             toPersonEmail,
+		Message:                   config.Settings.Get("message").MustString(defaultWebexMessage),
+	}, nil
+}
+
+// WebexNotifier posts alert notifications to a Cisco Webex Teams room or person using the Webex messages API.
+type WebexNotifier struct {
+	conf *WebexConfig
+	log  log.Logger
+	ns   notifications.WebhookSender
+	tmpl *template.Template
+}
+
+// NewWebexNotifier returns a new notifier that sends alerts to Webex Teams.
+func NewWebexNotifier(conf *WebexConfig, ns notifications.WebhookSender, t *template.Template) *WebexNotifier {
+	return &WebexNotifier{
+		conf: conf,
+		log:  log.New("alerting.notifier.webex"),
+		ns:   ns,
+		tmpl: t,
+	}
+}
+
+// webexMessage is the payload accepted by the Webex /v1/messages API.
+type webexMessage struct {
+	RoomID        string `json:"roomId,omitempty"`
+	ToPersonEmail string `json:"toPersonEmail,omitempty"`
+	Markdown      string `json:"markdown"`
+}
+
+// Notify implements the Notifier interface. The Markdown field is built from the templated
+// message before anything is sent, so a broken template is reported as an error rather than
+// posting a garbled message to the room.
+func (wn *WebexNotifier) Notify(ctx context.Context, as ...*types.Alert) (bool, error) {
+	var tmplErr error
+	tmpl, _ := TmplText(ctx, wn.tmpl, as, wn.log, &tmplErr)
+
+	msg := &webexMessage{
+		RoomID:        wn.conf.RoomID,
+		ToPersonEmail: wn.conf.ToPersonEmail,
+		Markdown:      tmpl(wn.conf.Message),
+	}
+	if tmplErr != nil {
+		return false, tmplErr
+	}
+
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return false, fmt.Errorf("marshal Webex message: %w", err)
+	}
+
+	cmd := &models.SendWebhookSync{
+		Url:        webexAPIURL,
+		Body:       string(body),
+		HttpMethod: http.MethodPost,
+		HttpHeader: map[string]string{
+			"Authorization": fmt.Sprintf("Bearer %s", wn.conf.BotToken),
+			"Content-Type":  "application/json",
+		},
+		Validation: webexValidateResponse,
+	}
+
+	if err := wn.ns.SendWebhookSync(ctx, cmd); err != nil {
+		wn.log.Error("failed to send Webex message", "error", err, "webex_room", wn.conf.RoomID)
+		var apiErr *webexAPIError
+		if errors.As(err, &apiErr) {
+			return apiErr.retriable, apiErr
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+// webexAPIError carries whether a failed Webex API call is safe to retry.
+type webexAPIError struct {
+	retriable bool
+	err       error
+}
+
+func (e *webexAPIError) Error() string {
+	return e.err.Error()
+}
+
+func (e *webexAPIError) Unwrap() error {
+	return e.err
+}
+
+// webexValidateResponse turns a Webex API response into a retriable or terminal error.
+// Webex returns 429 on rate limiting and 5xx on transient outages, both of which are safe to retry;
+// any other non-2xx status is treated as a permanent failure of this notification attempt.
+func webexValidateResponse(b []byte, statusCode int) error {
+	if statusCode/100 == 2 {
+		return nil
+	}
+	err := fmt.Errorf("webex API returned status %d, body %s", statusCode, bytes.TrimSpace(b))
+	retriable := statusCode == http.StatusTooManyRequests || statusCode/100 == 5
+	return &webexAPIError{retriable: retriable, err: err}
+}
+
+func (wn *WebexNotifier) SendResolved() bool {
+	return !wn.conf.DisableResolveMessage
+}
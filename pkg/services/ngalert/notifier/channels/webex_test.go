@@ -0,0 +1,178 @@
+package channels
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/types"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/components/simplejson"
+)
+
+func TestWebexNotifier(t *testing.T) {
+	tmpl := templateForTests(t)
+
+	externalURL, err := url.Parse("http://localhost")
+	require.NoError(t, err)
+	tmpl.ExternalURL = externalURL
+
+	cases := []struct {
+		name         string
+		settings     string
+		alerts       []*types.Alert
+		expMsg       map[string]interface{}
+		expInitError string
+		expMsgError  error
+	}{
+		{
+			name:     "Default config with one alert, room_id",
+			settings: `{"bot_token": "test-token", "room_id": "test-room"}`,
+			alerts: []*types.Alert{
+				{
+					Alert: model.Alert{
+						Labels:      model.LabelSet{"alertname": "alert1", "lbl1": "val1"},
+						Annotations: model.LabelSet{"ann1": "annv1"},
+					},
+				},
+			},
+			expMsg: map[string]interface{}{
+				"roomId":   "test-room",
+				"markdown": "**Firing**\n\nValue: [no value]\nLabels:\n - alertname = alert1\n - lbl1 = val1\nAnnotations:\n - ann1 = annv1\nSilence: http://localhost/alerting/silence/new?alertmanager=grafana&matcher=alertname%3Dalert1&matcher=lbl1%3Dval1\n",
+			},
+			expMsgError: nil,
+		},
+		{
+			name:     "Custom message, to_person_email",
+			settings: `{"bot_token": "test-token", "to_person_email": "oncall@example.com", "message": "{{ len .Alerts.Firing }} firing"}`,
+			alerts: []*types.Alert{
+				{
+					Alert: model.Alert{
+						Labels:      model.LabelSet{"alertname": "alert1"},
+						Annotations: model.LabelSet{},
+					},
+				},
+			},
+			expMsg: map[string]interface{}{
+				"toPersonEmail": "oncall@example.com",
+				"markdown":      "1 firing",
+			},
+			expMsgError: nil,
+		},
+		{
+			name:     "Invalid template returns error",
+			settings: `{"bot_token": "test-token", "room_id": "test-room", "message": "{{ template \"invalid.template\" }}"}`,
+			alerts: []*types.Alert{
+				{
+					Alert: model.Alert{
+						Labels: model.LabelSet{"alertname": "alert1"},
+					},
+				},
+			},
+			expMsgError: errors.New("template: :1:12: executing \"\" at <{{template \"invalid.template\"}}>: template \"invalid.template\" not defined"),
+		},
+		{
+			name:         "Error in initialization, no token",
+			settings:     `{"room_id": "test-room"}`,
+			expInitError: `could not find Bot Token in settings`,
+		},
+		{
+			name:         "Error in initialization, no room_id or to_person_email",
+			settings:     `{"bot_token": "test-token"}`,
+			expInitError: `could not find room_id or to_person_email in settings`,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			settingsJson, err := simplejson.NewJson([]byte(c.settings))
+			require.NoError(t, err)
+
+			m := &NotificationChannelConfig{
+				Name:     "webex_testing",
+				Type:     "webex",
+				Settings: settingsJson,
+			}
+
+			webhookSender := mockNotificationService()
+			cfg, err := NewWebexConfig(m, decryptFn)
+			if c.expInitError != "" {
+				require.Equal(t, c.expInitError, err.Error())
+				return
+			}
+			require.NoError(t, err)
+
+			ctx := notify.WithGroupKey(context.Background(), "alertname")
+			ctx = notify.WithGroupLabels(ctx, model.LabelSet{"alertname": ""})
+			wn := NewWebexNotifier(cfg, webhookSender, tmpl)
+			ok, err := wn.Notify(ctx, c.alerts...)
+			if c.expMsgError != nil {
+				require.False(t, ok)
+				require.Error(t, err)
+				require.Equal(t, c.expMsgError.Error(), err.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.True(t, ok)
+
+			expBody, err := json.Marshal(c.expMsg)
+			require.NoError(t, err)
+
+			require.JSONEq(t, string(expBody), webhookSender.Webhook.Body)
+		})
+	}
+}
+
+func TestWebexNotifier_ResponseClassification(t *testing.T) {
+	tmpl := templateForTests(t)
+
+	externalURL, err := url.Parse("http://localhost")
+	require.NoError(t, err)
+	tmpl.ExternalURL = externalURL
+
+	alerts := []*types.Alert{
+		{
+			Alert: model.Alert{
+				Labels: model.LabelSet{"alertname": "alert1"},
+			},
+		},
+	}
+
+	cases := []struct {
+		name       string
+		statusCode int
+		expOK      bool
+	}{
+		{name: "429 is retriable", statusCode: http.StatusTooManyRequests, expOK: true},
+		{name: "503 is retriable", statusCode: http.StatusServiceUnavailable, expOK: true},
+		{name: "400 is a permanent failure", statusCode: http.StatusBadRequest, expOK: false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			settingsJson, err := simplejson.NewJson([]byte(`{"bot_token": "test-token", "room_id": "test-room"}`))
+			require.NoError(t, err)
+
+			cfg, err := NewWebexConfig(&NotificationChannelConfig{
+				Name:     "webex_testing",
+				Type:     "webex",
+				Settings: settingsJson,
+			}, decryptFn)
+			require.NoError(t, err)
+
+			webhookSender := mockNotificationService()
+			webhookSender.RespStatusCode = c.statusCode
+
+			wn := NewWebexNotifier(cfg, webhookSender, tmpl)
+			ok, err := wn.Notify(context.Background(), alerts...)
+			require.Error(t, err)
+			require.Equal(t, c.expOK, ok)
+		})
+	}
+}